@@ -0,0 +1,22 @@
+package bridges
+
+import "context"
+
+// Backend is the storage/query interface LocalKGBridge delegates to. It
+// exists so the historical Python subprocess implementation and the native
+// nebula-go client can be swapped without touching call sites.
+type Backend interface {
+	CaptureError(ctx context.Context, capture ErrorCapture) (string, error)
+	SearchPatterns(ctx context.Context, query string, limit int) ([]ErrorPattern, error)
+	AddSolution(ctx context.Context, patternID, solutionText, effectiveness string) (string, error)
+	GetSummary(ctx context.Context) (*PatternSummary, error)
+
+	// CaptureErrorWithParams and SearchPatternsWithParams accept native Go
+	// values (bool, int64, string, []interface{}, map[string]interface{})
+	// and are responsible for converting them to the backend's own value
+	// types, rather than having callers inline-format query strings.
+	CaptureErrorWithParams(ctx context.Context, params map[string]interface{}) (string, error)
+	SearchPatternsWithParams(ctx context.Context, params map[string]interface{}) ([]ErrorPattern, error)
+
+	Close() error
+}
@@ -1,7 +1,9 @@
 /**
  * Go Bridge for Nebula Local KG
  *
- * Provides a Go interface to interact with the Python-based Local Knowledge Graph.
+ * Provides a Go interface to interact with the Local Knowledge Graph,
+ * either through a native NebulaGraph client or (for backward
+ * compatibility) the original Python-based implementation.
  *
  * Usage:
  *   import "your-project/local_kg/bridges"
@@ -16,15 +18,18 @@
 package bridges
 
 import (
-	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strings"
 	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // NebulaConfig represents the configuration for the bridge
@@ -59,31 +64,58 @@ type ErrorPattern struct {
 	FirstSeen       string `json:"first_seen"`
 	LastSeen        string `json:"last_seen"`
 	SolutionCount   int    `json:"solution_count"`
+
+	// CausedBy holds the pattern ID of the next link out in an
+	// errors.Unwrap chain, if this pattern was captured as part of one.
+	CausedBy string       `json:"caused_by,omitempty"`
+	Frames   []StackFrame `json:"frames,omitempty"`
 }
 
 // PatternSummary represents statistics about patterns
 type PatternSummary struct {
-	TotalPatterns  int                    `json:"total_patterns"`
-	TotalSolutions int                    `json:"total_solutions"`
-	Languages      map[string]int         `json:"languages"`
+	TotalPatterns  int                      `json:"total_patterns"`
+	TotalSolutions int                      `json:"total_solutions"`
+	Languages      map[string]int           `json:"languages"`
 	TopErrors      []map[string]interface{} `json:"top_errors"`
 }
 
-// LocalKGBridge provides Go interface to Python Local KG
+// LocalKGBridge provides a Go interface to the Local KG. It delegates all
+// storage and query work to a Backend, which may be the native NebulaGraph
+// client or the legacy Python subprocess implementation.
 type LocalKGBridge struct {
-	config        NebulaConfig
-	dbPath        string
-	pythonCommand string
-	mu            sync.Mutex
+	config  NebulaConfig
+	dbPath  string
+	backend Backend
+	metrics *bridgeMetrics
+
+	syncMu sync.Mutex
+	sync   *syncState
+}
+
+// applyOptions runs opts against kg, falling back to a bridge-private
+// Prometheus registry if none of them set one via WithRegistry, then wires
+// kg.metrics into the backend's own failure reporting (if it has any) so
+// every call site contributes to the same signal automatically.
+func (kg *LocalKGBridge) applyOptions(opts []Option) {
+	for _, opt := range opts {
+		opt(kg)
+	}
+	if kg.metrics == nil {
+		kg.metrics = newBridgeMetrics(prometheus.NewRegistry())
+	}
+	if pb, ok := kg.backend.(*pythonBackend); ok {
+		pb.SetFailureHook(kg.metrics.recordPythonFailure)
+	}
 }
 
 var (
 	globalInstance *LocalKGBridge
-	once           sync.Once
+	globalOnce     sync.Once
 )
 
-// NewLocalKGBridge creates a new bridge instance
-func NewLocalKGBridge(dbPath string) (*LocalKGBridge, error) {
+// NewLocalKGBridge creates a new bridge instance backed by the Python
+// subprocess implementation, preserving the historical default behavior.
+func NewLocalKGBridge(dbPath string, opts ...Option) (*LocalKGBridge, error) {
 	config, err := loadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
@@ -101,11 +133,45 @@ func NewLocalKGBridge(dbPath string) (*LocalKGBridge, error) {
 		pythonCmd = "python"
 	}
 
-	return &LocalKGBridge{
-		config:        config,
-		dbPath:        dbPath,
-		pythonCommand: pythonCmd,
-	}, nil
+	kg := &LocalKGBridge{
+		config:  config,
+		dbPath:  dbPath,
+		backend: newPythonBackend(dbPath, pythonCmd),
+	}
+	kg.applyOptions(opts)
+	kg.startAutoSync()
+	return kg, nil
+}
+
+// NewNebulaLocalKGBridge creates a bridge backed directly by a NebulaGraph
+// cluster, bypassing the Python subprocess entirely.
+func NewNebulaLocalKGBridge(connCfg NebulaConnConfig, opts ...Option) (*LocalKGBridge, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	backend, err := newNebulaBackend(connCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kg := &LocalKGBridge{
+		config:  config,
+		dbPath:  connCfg.Space,
+		backend: backend,
+	}
+	kg.applyOptions(opts)
+	kg.startAutoSync()
+	return kg, nil
+}
+
+// NewLocalKGBridgeWithBackend wires up a bridge around a caller-supplied
+// Backend, mainly useful for tests and alternative storage engines.
+func NewLocalKGBridgeWithBackend(dbPath string, backend Backend, opts ...Option) *LocalKGBridge {
+	kg := &LocalKGBridge{dbPath: dbPath, backend: backend}
+	kg.applyOptions(opts)
+	return kg
 }
 
 // loadConfig loads configuration from .nebula/config.json or environment variables
@@ -146,127 +212,56 @@ func (kg *LocalKGBridge) CaptureError(signature, category, language, severity st
 
 // CaptureErrorWithDescription captures an error with optional description
 func (kg *LocalKGBridge) CaptureErrorWithDescription(signature, category, language, severity, description string) (string, error) {
-	descValue := "None"
-	if description != "" {
-		descValue = fmt.Sprintf("'%s'", escapePython(description))
+	start := time.Now()
+	id, err := kg.backend.CaptureError(context.Background(), ErrorCapture{
+		Signature:   signature,
+		Category:    category,
+		Language:    language,
+		Severity:    severity,
+		Description: description,
+	})
+	kg.metrics.observe("capture_error", language, severity, start, err)
+	if err == nil {
+		kg.enqueueOutbox("pattern", ErrorCapture{
+			Signature:   signature,
+			Category:    category,
+			Language:    language,
+			Severity:    severity,
+			Description: description,
+		})
 	}
-
-	pythonCode := fmt.Sprintf(`
-import sys
-sys.path.insert(0, '.')
-from local_kg.local_kg import get_local_kg
-
-kg = get_local_kg('%s')
-pattern_id = kg.capture_error(
-    error_signature='%s',
-    error_category='%s',
-    language='%s',
-    description=%s,
-    severity='%s'
-)
-print(pattern_id)
-`,
-		kg.dbPath,
-		escapePython(signature),
-		escapePython(category),
-		escapePython(language),
-		descValue,
-		escapePython(severity),
-	)
-
-	return kg.runPython(pythonCode)
-}
-
-// CaptureErrorFromError captures a Go error
-func (kg *LocalKGBridge) CaptureErrorFromError(err error, language, severity string) (string, error) {
-	return kg.CaptureError(
-		err.Error(),
-		fmt.Sprintf("%T", err),
-		language,
-		severity,
-	)
+	return id, err
 }
 
 // SearchPatterns searches for similar error patterns
 func (kg *LocalKGBridge) SearchPatterns(query string, limit int) ([]ErrorPattern, error) {
-	pythonCode := fmt.Sprintf(`
-import sys
-import json
-sys.path.insert(0, '.')
-from local_kg.local_kg import get_local_kg
-
-kg = get_local_kg('%s')
-patterns = kg.search_patterns('%s', %d)
-print(json.dumps(patterns, default=str))
-`,
-		kg.dbPath,
-		escapePython(query),
-		limit,
-	)
-
-	result, err := kg.runPython(pythonCode)
-	if err != nil {
-		return nil, err
-	}
-
-	var patterns []ErrorPattern
-	if err := json.Unmarshal([]byte(result), &patterns); err != nil {
-		return nil, fmt.Errorf("failed to parse patterns: %w", err)
-	}
-
-	return patterns, nil
+	start := time.Now()
+	patterns, err := kg.backend.SearchPatterns(context.Background(), query, limit)
+	kg.metrics.observe("search_patterns", kg.config.Language, "", start, err)
+	return patterns, err
 }
 
 // AddSolution adds a solution to an existing pattern
 func (kg *LocalKGBridge) AddSolution(patternID, solutionText, effectiveness string) (string, error) {
-	pythonCode := fmt.Sprintf(`
-import sys
-sys.path.insert(0, '.')
-from local_kg.local_kg import get_local_kg
-
-kg = get_local_kg('%s')
-solution_id = kg.add_solution(
-    pattern_id='%s',
-    solution_text='%s',
-    effectiveness='%s'
-)
-print(solution_id)
-`,
-		kg.dbPath,
-		escapePython(patternID),
-		escapePython(solutionText),
-		escapePython(effectiveness),
-	)
-
-	return kg.runPython(pythonCode)
+	start := time.Now()
+	id, err := kg.backend.AddSolution(context.Background(), patternID, solutionText, effectiveness)
+	kg.metrics.observe("add_solution", kg.config.Language, "", start, err)
+	if err == nil {
+		kg.enqueueOutbox("solution", solutionPayload{
+			PatternID:     patternID,
+			SolutionText:  solutionText,
+			Effectiveness: effectiveness,
+		})
+	}
+	return id, err
 }
 
 // GetSummary gets summary statistics
 func (kg *LocalKGBridge) GetSummary() (*PatternSummary, error) {
-	pythonCode := fmt.Sprintf(`
-import sys
-import json
-sys.path.insert(0, '.')
-from local_kg.local_kg import get_local_kg
-
-kg = get_local_kg('%s')
-summary = kg.get_pattern_summary()
-print(json.dumps(summary, default=str))
-`,
-		kg.dbPath,
-	)
-
-	result, err := kg.runPython(pythonCode)
-	if err != nil {
-		return nil, err
-	}
-
-	var summary PatternSummary
-	if err := json.Unmarshal([]byte(result), &summary); err != nil {
-		return nil, fmt.Errorf("failed to parse summary: %w", err)
-	}
-
-	return &summary, nil
+	start := time.Now()
+	summary, err := kg.backend.GetSummary(context.Background())
+	kg.metrics.observe("get_summary", kg.config.Language, "", start, err)
+	return summary, err
 }
 
 // CaptureErrorAsync captures error in a goroutine (non-blocking)
@@ -278,37 +273,17 @@ func (kg *LocalKGBridge) CaptureErrorAsync(signature, category, language, severi
 	}()
 }
 
-// escapePython escapes a string for Python code
-func escapePython(s string) string {
-	s = strings.ReplaceAll(s, "\\", "\\\\")
-	s = strings.ReplaceAll(s, "'", "\\'")
-	s = strings.ReplaceAll(s, "\n", "\\n")
-	return s
-}
-
-// runPython runs Python code and returns stdout
-func (kg *LocalKGBridge) runPython(code string) (string, error) {
-	kg.mu.Lock()
-	defer kg.mu.Unlock()
-
-	cmd := exec.Command(kg.pythonCommand, "-c", code)
-	cmd.Dir = "."
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("python process failed: %s: %w", stderr.String(), err)
-	}
-
-	return strings.TrimSpace(stdout.String()), nil
+// Close releases any resources held by the underlying backend (session
+// pools, worker processes, and the like).
+func (kg *LocalKGBridge) Close() error {
+	kg.StopSync()
+	return kg.backend.Close()
 }
 
 // GetGlobalInstance gets or creates the global singleton instance
 func GetGlobalInstance(dbPath string) (*LocalKGBridge, error) {
 	var err error
-	once.Do(func() {
+	globalOnce.Do(func() {
 		globalInstance, err = NewLocalKGBridge(dbPath)
 	})
 	return globalInstance, err
@@ -322,3 +297,14 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// newPatternID derives a stable, content-addressed ID for a captured
+// pattern or solution so repeated backends and offline/online sync agree
+// on identity without a central counter.
+func newPatternID(parts ...string) string {
+	h := sha1.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,240 @@
+package bridges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// StackFrame is one entry of a captured call stack.
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// captureTagsKey is the context key WithCaptureTags stores tags under.
+type captureTagsKey struct{}
+
+// WithCaptureTags attaches tags to ctx that CaptureErrorFromErrorWithContext
+// records alongside the capture (request IDs, tenant, environment, ...).
+// Tags from an outer WithCaptureTags call are preserved; a repeated key is
+// overwritten by the innermost call.
+func WithCaptureTags(ctx context.Context, tags map[string]string) context.Context {
+	merged := make(map[string]string, len(tags))
+	for k, v := range captureTagsFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, captureTagsKey{}, merged)
+}
+
+func captureTagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(captureTagsKey{}).(map[string]string)
+	return tags
+}
+
+// CaptureErrorFromError captures a Go error, walking its errors.Unwrap
+// chain and storing each link as its own pattern with CausedBy pointing at
+// the pattern above it, so an opaque err.Error() string becomes a
+// navigable chain instead of a single flattened message.
+func (kg *LocalKGBridge) CaptureErrorFromError(err error, language, severity string) (string, error) {
+	return kg.captureErrorChain(context.Background(), err, language, severity, nil)
+}
+
+// CaptureErrorFromErrorWithContext behaves like CaptureErrorFromError but
+// additionally records the goroutine ID, capturing function, and
+// file:line of the call site, plus any tags registered via
+// WithCaptureTags.
+func (kg *LocalKGBridge) CaptureErrorFromErrorWithContext(ctx context.Context, err error, language, severity string) (string, error) {
+	pc, file, line, _ := runtime.Caller(1)
+	site := &captureSite{
+		Function:    funcNameForPC(pc),
+		File:        file,
+		Line:        line,
+		GoroutineID: currentGoroutineID(),
+		Tags:        captureTagsFromContext(ctx),
+	}
+	return kg.captureErrorChain(ctx, err, language, severity, site)
+}
+
+// captureSite describes where a CaptureErrorFromErrorWithContext call was
+// made, recorded only on the outermost link of the chain.
+type captureSite struct {
+	Function    string
+	File        string
+	Line        int
+	GoroutineID int64
+	Tags        map[string]string
+}
+
+// captureErrorChain walks err's errors.Unwrap chain, capturing each error
+// as a pattern linked to the one before it via CausedBy.
+func (kg *LocalKGBridge) captureErrorChain(ctx context.Context, err error, language, severity string, site *captureSite) (string, error) {
+	if err == nil {
+		return "", fmt.Errorf("captureErrorChain: err is nil")
+	}
+
+	var rootID string
+	var causedBy string
+
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		params := map[string]interface{}{
+			"signature": current.Error(),
+			"category":  fmt.Sprintf("%T", current),
+			"language":  language,
+			"severity":  severity,
+		}
+
+		if frames := framesFor(current); len(frames) > 0 {
+			params["frames"] = framesToParam(frames)
+		}
+		if causedBy != "" {
+			params["caused_by"] = causedBy
+		}
+		if site != nil {
+			params["capture_function"] = site.Function
+			params["capture_file"] = site.File
+			params["capture_line"] = int64(site.Line)
+			params["goroutine_id"] = site.GoroutineID
+			if len(site.Tags) > 0 {
+				tags := make(map[string]interface{}, len(site.Tags))
+				for k, v := range site.Tags {
+					tags[k] = v
+				}
+				params["tags"] = tags
+			}
+			site = nil // only the outermost link records the capture site
+		}
+
+		id, err := kg.CaptureErrorWithParams(params)
+		if err != nil {
+			return "", fmt.Errorf("failed to capture error chain link %q: %w", current.Error(), err)
+		}
+
+		if rootID == "" {
+			rootID = id
+		}
+		causedBy = id
+	}
+
+	return rootID, nil
+}
+
+// framesFor extracts a stack trace from err if it exposes one via a
+// StackTrace() method, falling back to the current call stack via
+// runtime.Callers when it doesn't.
+func framesFor(err error) []StackFrame {
+	if frames := framesFromStackTrace(err); len(frames) > 0 {
+		return frames
+	}
+	return callerFrames(3) // skip callerFrames, framesFor, and captureErrorChain
+}
+
+// framesFromStackTrace looks for a StackTrace() method via reflection
+// rather than a same-named-method interface: github.com/pkg/errors returns
+// its own errors.StackTrace ([]errors.Frame, each an encoded program
+// counter), and a plain type assertion against a locally declared
+// interface never matches a concrete named return type. Any StackTrace()
+// method returning a slice of uintptr-kind values (covers pkg/errors'
+// Frame and similar conventions) is accepted, so the PCs can be fed
+// straight to runtime.CallersFrames.
+func framesFromStackTrace(err error) []StackFrame {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	trace := method.Call(nil)[0]
+	if trace.Kind() != reflect.Slice {
+		return nil
+	}
+
+	pcs := make([]uintptr, trace.Len())
+	for i := range pcs {
+		elem := trace.Index(i)
+		if elem.Kind() != reflect.Uintptr {
+			return nil
+		}
+		pcs[i] = uintptr(elem.Uint())
+	}
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(pcs)
+	var out []StackFrame
+	for {
+		frame, more := frames.Next()
+		out = append(out, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// callerFrames captures the live Go call stack starting `skip` frames up
+// from callerFrames itself.
+func callerFrames(skip int) []StackFrame {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+1, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(pcs[:n])
+	var out []StackFrame
+	for {
+		frame, more := frames.Next()
+		out = append(out, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+func framesToParam(frames []StackFrame) []interface{} {
+	out := make([]interface{}, len(frames))
+	for i, f := range frames {
+		out[i] = map[string]interface{}{
+			"function": f.Function,
+			"file":     f.File,
+			"line":     int64(f.Line),
+		}
+	}
+	return out
+}
+
+func funcNameForPC(pc uintptr) string {
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		return fn.Name()
+	}
+	return ""
+}
+
+// currentGoroutineID parses the "goroutine N [...]" header that
+// runtime.Stack writes, which is the only way to recover it without the
+// runtime/debug internals.
+func currentGoroutineID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	fields := strings.Fields(string(buf))
+	if len(fields) < 2 {
+		return 0
+	}
+
+	id, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
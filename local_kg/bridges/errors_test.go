@@ -0,0 +1,38 @@
+package bridges
+
+import (
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func errorFromDeepWithin() error {
+	return pkgerrors.New("boom")
+}
+
+func TestFramesForUsesPkgErrorsStackTrace(t *testing.T) {
+	err := errorFromDeepWithin()
+
+	frames := framesFor(err)
+	if len(frames) == 0 {
+		t.Fatal("expected frames from the error's own StackTrace()")
+	}
+
+	for _, f := range frames {
+		if f.Function == "github.com/JCorellaFSL/Nebula-Protocol/local_kg/bridges.errorFromDeepWithin" {
+			return
+		}
+	}
+	t.Fatalf("expected a frame for errorFromDeepWithin (the error's real origin), got %+v", frames)
+}
+
+func TestFramesForFallsBackForPlainErrors(t *testing.T) {
+	frames := framesFor(errStub{"plain"})
+	if len(frames) == 0 {
+		t.Fatal("expected fallback call-stack frames for an error without StackTrace()")
+	}
+}
+
+type errStub struct{ msg string }
+
+func (e errStub) Error() string { return e.msg }
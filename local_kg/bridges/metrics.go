@@ -0,0 +1,89 @@
+package bridges
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// bridgeMetrics holds the Prometheus collectors for a LocalKGBridge. Each
+// bridge gets its own registry by default so multiple bridges (or tests)
+// don't collide on the global default registry.
+type bridgeMetrics struct {
+	registry *prometheus.Registry
+
+	operations       *prometheus.CounterVec
+	operationLatency *prometheus.HistogramVec
+	pythonFailures   prometheus.Counter
+}
+
+func newBridgeMetrics(registry *prometheus.Registry) *bridgeMetrics {
+	m := &bridgeMetrics{
+		registry: registry,
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "nebula_kg",
+			Name:      "bridge_operations_total",
+			Help:      "Total LocalKGBridge operations by type and outcome.",
+		}, []string{"operation", "language", "severity", "outcome"}),
+		operationLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "nebula_kg",
+			Name:      "bridge_operation_duration_seconds",
+			Help:      "Latency of LocalKGBridge operations.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		pythonFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "nebula_kg",
+			Name:      "bridge_python_subprocess_failures_total",
+			Help:      "Total failures from the Python worker subprocess.",
+		}),
+	}
+
+	registry.MustRegister(m.operations, m.operationLatency, m.pythonFailures)
+
+	return m
+}
+
+// observe records the outcome and latency of a single backend call.
+func (m *bridgeMetrics) observe(operation, language, severity string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	m.operations.WithLabelValues(operation, language, severity, outcome).Inc()
+	m.operationLatency.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// recordPythonFailure increments the python subprocess failure counter,
+// giving operators a metric to alert on instead of scraping stderr.
+func (m *bridgeMetrics) recordPythonFailure() {
+	if m == nil {
+		return
+	}
+	m.pythonFailures.Inc()
+}
+
+// MetricsHandler returns an http.Handler exposing this bridge's metrics in
+// the Prometheus exposition format, ready to be mounted by the caller's own
+// HTTP server.
+func (kg *LocalKGBridge) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(kg.metrics.registry, promhttp.HandlerOpts{})
+}
+
+// Option configures optional LocalKGBridge behavior at construction time.
+type Option func(*LocalKGBridge)
+
+// WithRegistry mounts the bridge's Prometheus collectors on a
+// caller-supplied registry instead of a bridge-private one, so operators
+// can expose it alongside their own application metrics.
+func WithRegistry(registry *prometheus.Registry) Option {
+	return func(kg *LocalKGBridge) {
+		kg.metrics = newBridgeMetrics(registry)
+	}
+}
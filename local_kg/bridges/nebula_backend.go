@@ -0,0 +1,572 @@
+package bridges
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	nebula "github.com/vesoft-inc/nebula-go/v2"
+)
+
+// NebulaConnConfig describes how to reach the NebulaGraph cluster backing a
+// nebulaBackend.
+type NebulaConnConfig struct {
+	Hosts       []string // "host:port" pairs
+	Username    string
+	Password    string
+	Space       string
+	PoolSize    int
+	IdleTime    time.Duration
+	TimeoutSecs int
+}
+
+// nebulaBackend talks to NebulaGraph directly over a pooled session,
+// replacing the Python subprocess round-trip with native nGQL queries.
+//
+// nebula-go v2 has no parameterized-query API (no ExecuteWithParameter,
+// no session pool with functional options) — every statement is built as
+// plain nGQL text. Values are encoded to nGQL literals by nGQLLiteral
+// instead of being interpolated with fmt.Sprintf, so callers still work
+// with typed Go values rather than hand-escaped strings.
+type nebulaBackend struct {
+	pool *nebula.ConnectionPool
+
+	mu      sync.Mutex // nebula-go sessions are not safe for concurrent use
+	session *nebula.Session
+}
+
+// newNebulaBackend dials the cluster described by cfg, opens a session,
+// and switches it to cfg.Space.
+func newNebulaBackend(cfg NebulaConnConfig) (*nebulaBackend, error) {
+	if len(cfg.Hosts) == 0 {
+		return nil, fmt.Errorf("nebula: at least one host is required")
+	}
+	if cfg.Space == "" {
+		return nil, fmt.Errorf("nebula: space is required")
+	}
+
+	addresses := make([]nebula.HostAddress, 0, len(cfg.Hosts))
+	for _, h := range cfg.Hosts {
+		host, port, err := splitHostPort(h)
+		if err != nil {
+			return nil, fmt.Errorf("nebula: invalid host %q: %w", h, err)
+		}
+		addresses = append(addresses, nebula.HostAddress{Host: host, Port: port})
+	}
+
+	poolConf := nebula.GetDefaultConf()
+	poolConf.TimeOut = time.Duration(cfg.TimeoutSecs) * time.Second
+	poolConf.IdleTime = cfg.IdleTime
+	if cfg.PoolSize > 0 {
+		poolConf.MaxConnPoolSize = cfg.PoolSize
+	}
+
+	pool, err := nebula.NewConnectionPool(addresses, poolConf, nebula.DefaultLogger{})
+	if err != nil {
+		return nil, fmt.Errorf("nebula: failed to open connection pool: %w", err)
+	}
+
+	session, err := pool.GetSession(cfg.Username, cfg.Password)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("nebula: failed to open session: %w", err)
+	}
+
+	if rs, err := session.Execute(fmt.Sprintf("USE %s", cfg.Space)); err != nil || !rs.IsSucceed() {
+		session.Release()
+		pool.Close()
+		if err != nil {
+			return nil, fmt.Errorf("nebula: failed to select space %q: %w", cfg.Space, err)
+		}
+		return nil, fmt.Errorf("nebula: failed to select space %q: %s", cfg.Space, rs.GetErrorMsg())
+	}
+
+	return &nebulaBackend{pool: pool, session: session}, nil
+}
+
+// splitHostPort parses a "host:port" pair, the shape NebulaConnConfig.Hosts
+// is documented to take.
+func splitHostPort(addr string) (string, int, error) {
+	host, portStr, found := strings.Cut(addr, ":")
+	if !found {
+		return "", 0, fmt.Errorf("expected host:port")
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port %q: %w", portStr, err)
+	}
+	return host, port, nil
+}
+
+func (n *nebulaBackend) CaptureError(ctx context.Context, c ErrorCapture) (string, error) {
+	return n.captureError(ctx, map[string]interface{}{
+		"signature":   c.Signature,
+		"category":    c.Category,
+		"language":    c.Language,
+		"severity":    c.Severity,
+		"description": c.Description,
+	})
+}
+
+func (n *nebulaBackend) SearchPatterns(ctx context.Context, query string, limit int) ([]ErrorPattern, error) {
+	return n.searchPatterns(ctx, map[string]interface{}{
+		"query": query,
+		"limit": int64(limit),
+	})
+}
+
+func (n *nebulaBackend) AddSolution(ctx context.Context, patternID, solutionText, effectiveness string) (string, error) {
+	id := newPatternID(solutionText, patternID)
+
+	solutionLit, err := nGQLLiteral(solutionText)
+	if err != nil {
+		return "", fmt.Errorf("nebula: add solution failed: %w", err)
+	}
+	effectivenessLit, err := nGQLLiteral(effectiveness)
+	if err != nil {
+		return "", fmt.Errorf("nebula: add solution failed: %w", err)
+	}
+
+	stmt := fmt.Sprintf(
+		`INSERT VERTEX solution(solution_text, effectiveness) VALUES %q:(%s, %s); `+
+			`INSERT EDGE has_solution() VALUES %q->%q:()`,
+		id, solutionLit, effectivenessLit,
+		patternID, id,
+	)
+
+	if _, err := n.execute(ctx, stmt); err != nil {
+		return "", fmt.Errorf("nebula: add solution failed: %w", err)
+	}
+
+	return id, nil
+}
+
+func (n *nebulaBackend) GetSummary(ctx context.Context) (*PatternSummary, error) {
+	resultSet, err := n.execute(ctx, `MATCH (v:error_pattern) RETURN v.error_pattern.language AS language, count(*) AS total`)
+	if err != nil {
+		return nil, fmt.Errorf("nebula: get summary failed: %w", err)
+	}
+
+	summary, err := summaryFromRows(resultSet)
+	if err != nil {
+		return nil, fmt.Errorf("nebula: get summary failed: %w", err)
+	}
+
+	solutionCount, err := n.countVertices(ctx, "solution")
+	if err != nil {
+		return nil, fmt.Errorf("nebula: get summary failed: %w", err)
+	}
+	summary.TotalSolutions = solutionCount
+
+	return summary, nil
+}
+
+// countVertices returns the number of vertices carrying tag, used for the
+// solution count GetSummary reports alongside the per-language pattern
+// breakdown.
+func (n *nebulaBackend) countVertices(ctx context.Context, tag string) (int, error) {
+	rs, err := n.execute(ctx, fmt.Sprintf(`MATCH (v:%s) RETURN count(*) AS total`, tag))
+	if err != nil {
+		return 0, err
+	}
+	if rs.GetRowSize() == 0 {
+		return 0, nil
+	}
+
+	row, err := rs.GetRowValuesByIndex(0)
+	if err != nil {
+		return 0, err
+	}
+	totalVal, err := row.GetValueByColName("total")
+	if err != nil {
+		return 0, err
+	}
+	total, err := totalVal.AsInt()
+	if err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+func (n *nebulaBackend) CaptureErrorWithParams(ctx context.Context, params map[string]interface{}) (string, error) {
+	return n.captureError(ctx, params)
+}
+
+func (n *nebulaBackend) SearchPatternsWithParams(ctx context.Context, params map[string]interface{}) ([]ErrorPattern, error) {
+	return n.searchPatterns(ctx, params)
+}
+
+// captureError upserts the error_pattern vertex for (signature, language),
+// bumping occurrence_count and preserving first_seen across repeat
+// captures (and setting both on first capture) so this backend merges
+// identical captures the same way the Python and sqlite backends do —
+// an assumption sync.go's PullFromCentral relies on.
+func (n *nebulaBackend) captureError(ctx context.Context, params map[string]interface{}) (string, error) {
+	signature, _ := params["signature"].(string)
+	id := newPatternID(signature, fmt.Sprint(params["language"]))
+
+	occurrenceCount, firstSeen, found, err := n.existingPatternMeta(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("nebula: capture error failed: %w", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if found {
+		occurrenceCount++
+	} else {
+		occurrenceCount = 1
+		firstSeen = now
+	}
+
+	props := make(map[string]interface{}, len(params)+3)
+	for k, v := range params {
+		props[k] = v
+	}
+	props["occurrence_count"] = occurrenceCount
+	props["first_seen"] = firstSeen
+	props["last_seen"] = now
+
+	propNames := make([]string, 0, len(props))
+	for k := range props {
+		propNames = append(propNames, k)
+	}
+
+	stmt, err := buildInsertVertexStmt("error_pattern", id, propNames, props)
+	if err != nil {
+		return "", fmt.Errorf("nebula: capture error failed: %w", err)
+	}
+
+	if _, err := n.execute(ctx, stmt); err != nil {
+		return "", fmt.Errorf("nebula: capture error failed: %w", err)
+	}
+
+	return id, nil
+}
+
+// existingPatternMeta fetches the occurrence_count/first_seen recorded for
+// an error_pattern vertex, if one already exists with this id.
+func (n *nebulaBackend) existingPatternMeta(ctx context.Context, id string) (occurrenceCount int64, firstSeen string, found bool, err error) {
+	idLit, err := nGQLLiteral(id)
+	if err != nil {
+		return 0, "", false, err
+	}
+
+	rs, err := n.execute(ctx, fmt.Sprintf(
+		`FETCH PROP ON error_pattern %s YIELD error_pattern.occurrence_count AS occurrence_count, error_pattern.first_seen AS first_seen`,
+		idLit,
+	))
+	if err != nil {
+		return 0, "", false, err
+	}
+	if rs.GetRowSize() == 0 {
+		return 0, "", false, nil
+	}
+
+	row, err := rs.GetRowValuesByIndex(0)
+	if err != nil {
+		return 0, "", false, err
+	}
+
+	countVal, err := row.GetValueByColName("occurrence_count")
+	if err != nil {
+		return 0, "", false, err
+	}
+	count, err := countVal.AsInt()
+	if err != nil {
+		return 0, "", false, err
+	}
+
+	seenVal, err := row.GetValueByColName("first_seen")
+	if err != nil {
+		return 0, "", false, err
+	}
+	seen, err := seenVal.AsString()
+	if err != nil {
+		return 0, "", false, err
+	}
+
+	return count, seen, true, nil
+}
+
+// patternYieldClause names every error_pattern column (plus the vertex id)
+// that searchPatterns needs back, so LOOKUP's implicit column set doesn't
+// leave patternFromRecord looking up columns that were never returned.
+const patternYieldClause = `id(vertex) AS id, ` +
+	`error_pattern.signature AS signature, ` +
+	`error_pattern.category AS category, ` +
+	`error_pattern.language AS language, ` +
+	`error_pattern.severity AS severity, ` +
+	`error_pattern.description AS description, ` +
+	`error_pattern.occurrence_count AS occurrence_count, ` +
+	`error_pattern.first_seen AS first_seen, ` +
+	`error_pattern.last_seen AS last_seen`
+
+// buildLookupStmt builds the LOOKUP statement searchPatterns runs,
+// pulled out as its own function so its exact text (in particular the
+// YIELD clause) can be tested without a live NebulaGraph session.
+func buildLookupStmt(query string, limit int64) (string, error) {
+	queryLit, err := nGQLLiteral(query)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		`LOOKUP ON error_pattern WHERE error_pattern.signature CONTAINS %s YIELD %s | LIMIT %d`,
+		queryLit, patternYieldClause, limit,
+	), nil
+}
+
+func (n *nebulaBackend) searchPatterns(ctx context.Context, params map[string]interface{}) ([]ErrorPattern, error) {
+	query, _ := params["query"].(string)
+	limit := int64(20)
+	if l, ok := params["limit"].(int64); ok && l > 0 {
+		limit = l
+	}
+
+	stmt, err := buildLookupStmt(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("nebula: search patterns failed: %w", err)
+	}
+
+	resultSet, err := n.execute(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("nebula: search patterns failed: %w", err)
+	}
+
+	return rowsToPatterns(resultSet)
+}
+
+// buildInsertVertexStmt produces an INSERT VERTEX statement for an
+// arbitrary set of properties, encoding every value through nGQLLiteral so
+// callers never hand-format query text themselves.
+func buildInsertVertexStmt(tag, id string, propNames []string, propValues map[string]interface{}) (string, error) {
+	literals := make([]string, len(propNames))
+	for i, name := range propNames {
+		lit, err := nGQLLiteral(propValues[name])
+		if err != nil {
+			return "", fmt.Errorf("property %q: %w", name, err)
+		}
+		literals[i] = lit
+	}
+
+	return fmt.Sprintf(
+		"INSERT VERTEX %s(%s) VALUES %q:(%s)",
+		tag,
+		strings.Join(propNames, ", "),
+		id,
+		strings.Join(literals, ", "),
+	), nil
+}
+
+// execute runs a single nGQL statement against the session, serializing
+// access (nebula-go sessions aren't safe for concurrent use) and
+// respecting ctx cancellation.
+func (n *nebulaBackend) execute(ctx context.Context, stmt string) (*nebula.ResultSet, error) {
+	type result struct {
+		rs  *nebula.ResultSet
+		err error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		n.mu.Lock()
+		rs, err := n.session.Execute(stmt)
+		n.mu.Unlock()
+		done <- result{rs: rs, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		if !r.rs.IsSucceed() {
+			return nil, fmt.Errorf("nGQL error %d: %s", r.rs.GetErrorCode(), r.rs.GetErrorMsg())
+		}
+		return r.rs, nil
+	}
+}
+
+// nGQLLiteral encodes a Go value (as produced by normalizeParamValue) into
+// an nGQL literal, quoting/escaping strings and recursing into lists and
+// maps. This is the closest nebula-go v2 gets to parameter binding: it has
+// no server-side bind-parameter support, so every value still ends up in
+// the query text, but never via raw string formatting.
+func nGQLLiteral(v interface{}) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64), nil
+	case string:
+		return quoteNGQL(val), nil
+	case []interface{}:
+		items := make([]string, len(val))
+		for i, item := range val {
+			lit, err := nGQLLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = lit
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(val))
+		for k, item := range val {
+			lit, err := nGQLLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, quoteNGQL(k)+": "+lit)
+		}
+		return "{" + strings.Join(pairs, ", ") + "}", nil
+	default:
+		return "", fmt.Errorf("unsupported nGQL literal type %T", v)
+	}
+}
+
+// quoteNGQL escapes a string literal for inclusion in an nGQL statement.
+func quoteNGQL(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// rowsToPatterns and summaryFromRows adapt nebula-go result sets to our
+// existing JSON-shaped types so callers don't need to know which backend
+// produced them.
+func rowsToPatterns(rs *nebula.ResultSet) ([]ErrorPattern, error) {
+	patterns := make([]ErrorPattern, 0, rs.GetRowSize())
+	for i := 0; i < rs.GetRowSize(); i++ {
+		row, err := rs.GetRowValuesByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := patternFromRecord(row)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// patternFromRecord reads one row of a patternYieldClause-shaped result
+// (searchPatterns' LOOKUP) back into an ErrorPattern. It uses AsString/AsInt
+// rather than the debug-oriented ValueWrapper.String(), which wraps string
+// values in literal quotes.
+func patternFromRecord(row *nebula.Record) (ErrorPattern, error) {
+	str := func(col string) (string, error) {
+		v, err := row.GetValueByColName(col)
+		if err != nil {
+			return "", fmt.Errorf("column %q: %w", col, err)
+		}
+		if v.IsNull() {
+			return "", nil
+		}
+		return v.AsString()
+	}
+
+	id, err := str("id")
+	if err != nil {
+		return ErrorPattern{}, err
+	}
+	signature, err := str("signature")
+	if err != nil {
+		return ErrorPattern{}, err
+	}
+	category, err := str("category")
+	if err != nil {
+		return ErrorPattern{}, err
+	}
+	language, err := str("language")
+	if err != nil {
+		return ErrorPattern{}, err
+	}
+	severity, err := str("severity")
+	if err != nil {
+		return ErrorPattern{}, err
+	}
+	description, err := str("description")
+	if err != nil {
+		return ErrorPattern{}, err
+	}
+	firstSeen, err := str("first_seen")
+	if err != nil {
+		return ErrorPattern{}, err
+	}
+	lastSeen, err := str("last_seen")
+	if err != nil {
+		return ErrorPattern{}, err
+	}
+
+	occurrenceCount := 0
+	countVal, err := row.GetValueByColName("occurrence_count")
+	if err != nil {
+		return ErrorPattern{}, fmt.Errorf("column %q: %w", "occurrence_count", err)
+	}
+	if !countVal.IsNull() {
+		count, err := countVal.AsInt()
+		if err != nil {
+			return ErrorPattern{}, err
+		}
+		occurrenceCount = int(count)
+	}
+
+	return ErrorPattern{
+		ID:              id,
+		ErrorSignature:  signature,
+		ErrorCategory:   category,
+		Language:        language,
+		Severity:        severity,
+		Description:     description,
+		OccurrenceCount: occurrenceCount,
+		FirstSeen:       firstSeen,
+		LastSeen:        lastSeen,
+	}, nil
+}
+
+func summaryFromRows(rs *nebula.ResultSet) (*PatternSummary, error) {
+	summary := &PatternSummary{Languages: map[string]int{}}
+	for i := 0; i < rs.GetRowSize(); i++ {
+		row, err := rs.GetRowValuesByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		langVal, err := row.GetValueByColName("language")
+		if err != nil {
+			continue
+		}
+		totalVal, err := row.GetValueByColName("total")
+		if err != nil {
+			continue
+		}
+		total, err := totalVal.AsInt()
+		if err != nil {
+			continue
+		}
+		language, err := langVal.AsString()
+		if err != nil {
+			continue
+		}
+		summary.Languages[language] = int(total)
+		summary.TotalPatterns += int(total)
+	}
+	return summary, nil
+}
+
+func (n *nebulaBackend) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.session.Release()
+	n.pool.Close()
+	return nil
+}
@@ -0,0 +1,59 @@
+package bridges
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNGQLLiteralEscapesStrings(t *testing.T) {
+	lit, err := nGQLLiteral(`it's "quoted" and has a \ in it`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `"it's \"quoted\" and has a \\ in it"`
+	if lit != want {
+		t.Fatalf("nGQLLiteral() = %s, want %s", lit, want)
+	}
+}
+
+func TestNGQLLiteralRejectsUnsupportedTypes(t *testing.T) {
+	if _, err := nGQLLiteral(struct{}{}); err == nil {
+		t.Fatal("expected an error for an unsupported literal type")
+	}
+}
+
+func TestBuildLookupStmtYieldsEveryColumnPatternFromRecordReads(t *testing.T) {
+	stmt, err := buildLookupStmt(`it's "tricky"`, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `LOOKUP ON error_pattern WHERE error_pattern.signature CONTAINS "it's \"tricky\"" YIELD ` +
+		`id(vertex) AS id, error_pattern.signature AS signature, error_pattern.category AS category, ` +
+		`error_pattern.language AS language, error_pattern.severity AS severity, error_pattern.description AS description, ` +
+		`error_pattern.occurrence_count AS occurrence_count, error_pattern.first_seen AS first_seen, error_pattern.last_seen AS last_seen | LIMIT 10`
+	if stmt != want {
+		t.Fatalf("buildLookupStmt() = %s, want %s", stmt, want)
+	}
+
+	for _, col := range []string{"id", "signature", "category", "language", "severity", "description", "occurrence_count", "first_seen", "last_seen"} {
+		if !strings.Contains(stmt, "AS "+col) {
+			t.Fatalf("buildLookupStmt() YIELD clause is missing column %q that patternFromRecord reads: %s", col, stmt)
+		}
+	}
+}
+
+func TestBuildInsertVertexStmtEncodesEveryValue(t *testing.T) {
+	stmt, err := buildInsertVertexStmt("error_pattern", "abc123", []string{"signature", "severity"}, map[string]interface{}{
+		"signature": `injected"); DROP SPACE test; --`,
+		"severity":  "high",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `INSERT VERTEX error_pattern(signature, severity) VALUES "abc123":("injected\"); DROP SPACE test; --", "high")`
+	if stmt != want {
+		t.Fatalf("buildInsertVertexStmt() = %s, want %s", stmt, want)
+	}
+}
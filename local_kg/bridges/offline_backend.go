@@ -0,0 +1,231 @@
+package bridges
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite" // pure-Go sqlite driver, no cgo
+)
+
+// currentSchemaVersion is the schema version this offline backend writes
+// and expects to read. It must stay in lockstep with the schema the Python
+// side (local_kg/local_kg.py) creates so records are visible to either tool.
+const currentSchemaVersion = 1
+
+// schemaMigrations are applied in order starting from whatever version is
+// currently recorded in schema_meta, so an older offline DB (or one first
+// created by the Python side) gets brought up to currentSchemaVersion.
+var schemaMigrations = []string{
+	// version 1
+	`CREATE TABLE IF NOT EXISTS schema_meta (version INTEGER NOT NULL);
+	 CREATE TABLE IF NOT EXISTS error_patterns (
+		id               TEXT PRIMARY KEY,
+		error_signature  TEXT NOT NULL,
+		error_category   TEXT NOT NULL,
+		language         TEXT NOT NULL,
+		severity         TEXT NOT NULL,
+		description      TEXT,
+		occurrence_count INTEGER NOT NULL DEFAULT 1,
+		first_seen       TEXT NOT NULL,
+		last_seen        TEXT NOT NULL
+	 );
+	 CREATE TABLE IF NOT EXISTS solutions (
+		id             TEXT PRIMARY KEY,
+		pattern_id     TEXT NOT NULL REFERENCES error_patterns(id),
+		solution_text  TEXT NOT NULL,
+		effectiveness  TEXT NOT NULL,
+		created_at     TEXT NOT NULL
+	 );`,
+}
+
+// sqliteBackend implements Backend directly against the SQLite file the
+// Python Local KG writes, via database/sql + modernc.org/sqlite (pure Go,
+// no cgo). It lets Go-only environments (CI runners, minimal containers,
+// air-gapped hosts) capture and query patterns without a Python runtime.
+type sqliteBackend struct {
+	db *sql.DB
+}
+
+// NewLocalKGBridgeOffline opens dbPath directly through database/sql,
+// running any pending schema migrations, and wires a LocalKGBridge around
+// it. Records written offline use the same schema as the Python side, so
+// they're visible to Python tooling the next time it runs against dbPath.
+func NewLocalKGBridgeOffline(dbPath string, opts ...Option) (*LocalKGBridge, error) {
+	backend, err := newSQLiteBackend(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	kg := &LocalKGBridge{dbPath: dbPath, backend: backend}
+	kg.applyOptions(opts)
+	return kg, nil
+}
+
+func newSQLiteBackend(dbPath string) (*sqliteBackend, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("offline kg: failed to open %s: %w", dbPath, err)
+	}
+
+	if err := migrateSchema(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &sqliteBackend{db: db}, nil
+}
+
+// migrateSchema brings dbPath's schema up to currentSchemaVersion,
+// applying any migration whose index is beyond the version already
+// recorded there.
+func migrateSchema(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_meta (version INTEGER NOT NULL)`); err != nil {
+		return fmt.Errorf("offline kg: failed to prepare schema_meta: %w", err)
+	}
+
+	var version int
+	row := db.QueryRow(`SELECT version FROM schema_meta LIMIT 1`)
+	if err := row.Scan(&version); err == sql.ErrNoRows {
+		version = 0
+	} else if err != nil {
+		return fmt.Errorf("offline kg: failed to read schema version: %w", err)
+	}
+
+	for v := version; v < len(schemaMigrations); v++ {
+		if _, err := db.Exec(schemaMigrations[v]); err != nil {
+			return fmt.Errorf("offline kg: migration %d failed: %w", v+1, err)
+		}
+	}
+
+	if version == 0 {
+		if _, err := db.Exec(`INSERT INTO schema_meta(version) VALUES (?)`, currentSchemaVersion); err != nil {
+			return fmt.Errorf("offline kg: failed to record schema version: %w", err)
+		}
+	} else if version < currentSchemaVersion {
+		if _, err := db.Exec(`UPDATE schema_meta SET version = ?`, currentSchemaVersion); err != nil {
+			return fmt.Errorf("offline kg: failed to update schema version: %w", err)
+		}
+	} else if version > currentSchemaVersion {
+		return fmt.Errorf("offline kg: database schema version %d is newer than this binary supports (%d)", version, currentSchemaVersion)
+	}
+
+	return nil
+}
+
+func (s *sqliteBackend) CaptureError(ctx context.Context, c ErrorCapture) (string, error) {
+	id := newPatternID(c.Signature, c.Language)
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO error_patterns(id, error_signature, error_category, language, severity, description, occurrence_count, first_seen, last_seen)
+		 VALUES (?, ?, ?, ?, ?, ?, 1, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET occurrence_count = occurrence_count + 1, last_seen = excluded.last_seen`,
+		id, c.Signature, c.Category, c.Language, c.Severity, c.Description, now, now,
+	)
+	if err != nil {
+		return "", fmt.Errorf("offline kg: capture error failed: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *sqliteBackend) SearchPatterns(ctx context.Context, query string, limit int) ([]ErrorPattern, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, error_signature, error_category, language, severity, description, occurrence_count, first_seen, last_seen
+		 FROM error_patterns WHERE error_signature LIKE ? ORDER BY last_seen DESC LIMIT ?`,
+		"%"+query+"%", limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("offline kg: search patterns failed: %w", err)
+	}
+	defer rows.Close()
+
+	var patterns []ErrorPattern
+	for rows.Next() {
+		var p ErrorPattern
+		var description sql.NullString
+		if err := rows.Scan(&p.ID, &p.ErrorSignature, &p.ErrorCategory, &p.Language, &p.Severity, &description, &p.OccurrenceCount, &p.FirstSeen, &p.LastSeen); err != nil {
+			return nil, fmt.Errorf("offline kg: failed to scan pattern row: %w", err)
+		}
+		p.Description = description.String
+		patterns = append(patterns, p)
+	}
+
+	return patterns, rows.Err()
+}
+
+func (s *sqliteBackend) AddSolution(ctx context.Context, patternID, solutionText, effectiveness string) (string, error) {
+	id := newPatternID(solutionText, patternID)
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO solutions(id, pattern_id, solution_text, effectiveness, created_at) VALUES (?, ?, ?, ?, ?)`,
+		id, patternID, solutionText, effectiveness, now,
+	)
+	if err != nil {
+		return "", fmt.Errorf("offline kg: add solution failed: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *sqliteBackend) GetSummary(ctx context.Context) (*PatternSummary, error) {
+	summary := &PatternSummary{Languages: map[string]int{}}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT language, COUNT(*) FROM error_patterns GROUP BY language`)
+	if err != nil {
+		return nil, fmt.Errorf("offline kg: get summary failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var language string
+		var count int
+		if err := rows.Scan(&language, &count); err != nil {
+			return nil, fmt.Errorf("offline kg: failed to scan summary row: %w", err)
+		}
+		summary.Languages[language] = count
+		summary.TotalPatterns += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM solutions`).Scan(&summary.TotalSolutions); err != nil {
+		return nil, fmt.Errorf("offline kg: failed to count solutions: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (s *sqliteBackend) CaptureErrorWithParams(ctx context.Context, params map[string]interface{}) (string, error) {
+	str := func(key string) string {
+		v, _ := params[key].(string)
+		return v
+	}
+
+	return s.CaptureError(ctx, ErrorCapture{
+		Signature:   str("signature"),
+		Category:    str("category"),
+		Language:    str("language"),
+		Severity:    str("severity"),
+		Description: str("description"),
+	})
+}
+
+func (s *sqliteBackend) SearchPatternsWithParams(ctx context.Context, params map[string]interface{}) ([]ErrorPattern, error) {
+	query, _ := params["query"].(string)
+
+	limit := 20
+	if l, ok := params["limit"].(int64); ok && l > 0 {
+		limit = int(l)
+	}
+
+	return s.SearchPatterns(ctx, query, limit)
+}
+
+func (s *sqliteBackend) Close() error {
+	return s.db.Close()
+}
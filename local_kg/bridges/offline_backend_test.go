@@ -0,0 +1,108 @@
+package bridges
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "offline.db"))
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrateSchemaFromEmptyDatabase(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := migrateSchema(db); err != nil {
+		t.Fatalf("migrateSchema() on empty db: %v", err)
+	}
+
+	var version int
+	if err := db.QueryRow(`SELECT version FROM schema_meta`).Scan(&version); err != nil {
+		t.Fatalf("failed to read schema version: %v", err)
+	}
+	if version != currentSchemaVersion {
+		t.Fatalf("schema_meta version = %d, want %d", version, currentSchemaVersion)
+	}
+
+	if _, err := db.Exec(`INSERT INTO error_patterns(id, error_signature, error_category, language, severity, first_seen, last_seen) VALUES ('1','sig','cat','go','high','now','now')`); err != nil {
+		t.Fatalf("error_patterns table not usable after migration: %v", err)
+	}
+}
+
+func TestMigrateSchemaIsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := migrateSchema(db); err != nil {
+		t.Fatalf("first migrateSchema(): %v", err)
+	}
+	if err := migrateSchema(db); err != nil {
+		t.Fatalf("second migrateSchema() on already-migrated db: %v", err)
+	}
+
+	var rowCount int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_meta`).Scan(&rowCount); err != nil {
+		t.Fatalf("failed to count schema_meta rows: %v", err)
+	}
+	if rowCount != 1 {
+		t.Fatalf("schema_meta has %d rows after two migrations, want exactly 1", rowCount)
+	}
+}
+
+func TestMigrateSchemaRejectsNewerVersion(t *testing.T) {
+	db := openTestDB(t)
+
+	if _, err := db.Exec(`CREATE TABLE schema_meta (version INTEGER NOT NULL)`); err != nil {
+		t.Fatalf("failed to seed schema_meta: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO schema_meta(version) VALUES (?)`, currentSchemaVersion+1); err != nil {
+		t.Fatalf("failed to seed future version: %v", err)
+	}
+
+	if err := migrateSchema(db); err == nil {
+		t.Fatal("expected migrateSchema() to reject a database from a newer schema version")
+	}
+}
+
+func TestSQLiteBackendCaptureErrorMergesOnSignatureAndLanguage(t *testing.T) {
+	backend, err := newSQLiteBackend(filepath.Join(t.TempDir(), "offline.db"))
+	if err != nil {
+		t.Fatalf("newSQLiteBackend() error: %v", err)
+	}
+	defer backend.Close()
+
+	ctx := context.Background()
+	capture := ErrorCapture{Signature: "boom", Category: "RuntimeError", Language: "go", Severity: "high"}
+
+	id1, err := backend.CaptureError(ctx, capture)
+	if err != nil {
+		t.Fatalf("first CaptureError() error: %v", err)
+	}
+	id2, err := backend.CaptureError(ctx, capture)
+	if err != nil {
+		t.Fatalf("second CaptureError() error: %v", err)
+	}
+	if id1 != id2 {
+		t.Fatalf("CaptureError() produced different IDs for the same signature/language: %s vs %s", id1, id2)
+	}
+
+	patterns, err := backend.SearchPatterns(ctx, "boom", 10)
+	if err != nil {
+		t.Fatalf("SearchPatterns() error: %v", err)
+	}
+	if len(patterns) != 1 {
+		t.Fatalf("SearchPatterns() returned %d patterns, want exactly 1", len(patterns))
+	}
+	if patterns[0].OccurrenceCount != 2 {
+		t.Fatalf("OccurrenceCount = %d, want 2", patterns[0].OccurrenceCount)
+	}
+}
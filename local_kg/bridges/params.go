@@ -0,0 +1,104 @@
+package bridges
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// requiredCaptureParams are the keys CaptureErrorWithParams needs to build a
+// valid ErrorCapture; everything else is passed through as extra metadata
+// (stack frames, tags, environment info, ...).
+var requiredCaptureParams = []string{"signature", "category", "language", "severity"}
+
+// CaptureErrorWithParams captures an error using native Go values instead of
+// inline-formatted query strings, so structured metadata can be attached
+// safely. Supported value types are nil, bool, int (converted to int64),
+// int64, float64, string, []interface{}, and map[string]interface{};
+// anything else is rejected.
+//
+// It records the same metrics and outbox entry CaptureErrorWithDescription
+// does, so richer captures routed through here (error chains, stack
+// frames, tags — see errors.go) are just as visible to Prometheus and
+// central sync as a plain string-arg capture.
+func (kg *LocalKGBridge) CaptureErrorWithParams(params map[string]interface{}) (string, error) {
+	normalized, err := normalizeParams(params)
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range requiredCaptureParams {
+		if _, ok := normalized[key]; !ok {
+			return "", fmt.Errorf("capture params missing required key %q", key)
+		}
+	}
+
+	language, _ := normalized["language"].(string)
+	severity, _ := normalized["severity"].(string)
+
+	start := time.Now()
+	id, err := kg.backend.CaptureErrorWithParams(context.Background(), normalized)
+	kg.metrics.observe("capture_error", language, severity, start, err)
+	if err == nil {
+		kg.enqueueOutbox("pattern", normalized)
+	}
+	return id, err
+}
+
+// SearchPatternsWithParams searches for patterns using a params map ("query"
+// is required; "limit" is optional and the backends default it to 20)
+// instead of positional arguments, leaving
+// room for backend-specific filters (language, severity, date range, ...).
+func (kg *LocalKGBridge) SearchPatternsWithParams(params map[string]interface{}) ([]ErrorPattern, error) {
+	normalized, err := normalizeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := normalized["query"]; !ok {
+		return nil, fmt.Errorf("search params missing required key %q", "query")
+	}
+
+	start := time.Now()
+	patterns, err := kg.backend.SearchPatternsWithParams(context.Background(), normalized)
+	kg.metrics.observe("search_patterns", kg.config.Language, "", start, err)
+	return patterns, err
+}
+
+// normalizeParams walks params and rejects any value that no supported
+// backend knows how to convert, so a caller gets a clear error up front
+// instead of a backend-specific marshaling failure later.
+func normalizeParams(params map[string]interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		converted, err := normalizeParamValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("param %q: %w", key, err)
+		}
+		out[key] = converted
+	}
+	return out, nil
+}
+
+func normalizeParamValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, bool, int64, float64, string:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			c, err := normalizeParamValue(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[i] = c
+		}
+		return converted, nil
+	case map[string]interface{}:
+		return normalizeParams(v)
+	default:
+		return nil, fmt.Errorf("unsupported param type %T", value)
+	}
+}
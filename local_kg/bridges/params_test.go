@@ -0,0 +1,77 @@
+package bridges
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCaptureErrorWithParamsRecordsMetricsAndOutbox(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "offline.db")
+
+	backend, err := newSQLiteBackend(dbPath)
+	if err != nil {
+		t.Fatalf("newSQLiteBackend() error: %v", err)
+	}
+	defer backend.Close()
+
+	registry := prometheus.NewRegistry()
+	kg := NewLocalKGBridgeWithBackend(dbPath, backend, WithRegistry(registry))
+
+	outboxDB, err := openOutbox(kg.dbPath)
+	if err != nil {
+		t.Fatalf("openOutbox() error: %v", err)
+	}
+	defer outboxDB.Close()
+	kg.sync = &syncState{db: outboxDB}
+
+	id, err := kg.CaptureErrorWithParams(map[string]interface{}{
+		"signature": "boom",
+		"category":  "RuntimeError",
+		"language":  "go",
+		"severity":  "high",
+	})
+	if err != nil {
+		t.Fatalf("CaptureErrorWithParams() error: %v", err)
+	}
+	if id == "" {
+		t.Fatal("expected a non-empty pattern id")
+	}
+
+	if got := testutil.ToFloat64(kg.metrics.operations.WithLabelValues("capture_error", "go", "high", "success")); got != 1 {
+		t.Fatalf("capture_error success counter = %v, want 1 (CaptureErrorWithParams must observe the same metric CaptureErrorWithDescription does)", got)
+	}
+
+	var pending int
+	if err := outboxDB.QueryRow(`SELECT COUNT(*) FROM outbox WHERE delivered = 0`).Scan(&pending); err != nil {
+		t.Fatalf("failed to query outbox: %v", err)
+	}
+	if pending != 1 {
+		t.Fatalf("pending outbox rows = %d, want 1 (CaptureErrorWithParams must enqueue for central sync too)", pending)
+	}
+}
+
+func TestSearchPatternsWithParamsRecordsMetrics(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "offline.db")
+
+	backend, err := newSQLiteBackend(dbPath)
+	if err != nil {
+		t.Fatalf("newSQLiteBackend() error: %v", err)
+	}
+	defer backend.Close()
+
+	registry := prometheus.NewRegistry()
+	kg := NewLocalKGBridgeWithBackend(dbPath, backend, WithRegistry(registry))
+	kg.config.Language = "go"
+
+	if _, err := kg.SearchPatternsWithParams(map[string]interface{}{"query": "boom"}); err != nil {
+		t.Fatalf("SearchPatternsWithParams() error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(kg.metrics.operations.WithLabelValues("search_patterns", "go", "", "success")); got != 1 {
+		t.Fatalf("search_patterns success counter = %v, want 1 (SearchPatternsWithParams must observe the same metric SearchPatterns does)", got)
+	}
+}
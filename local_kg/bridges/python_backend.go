@@ -0,0 +1,381 @@
+package bridges
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultWorkerQueueSize bounds how many in-flight RPC calls a pythonBackend
+// will queue before callers start blocking, so a stalled worker can't cause
+// unbounded goroutine/memory growth.
+const defaultWorkerQueueSize = 64
+
+// rpcRequest is one line of the newline-delimited JSON-RPC protocol spoken
+// with the Python worker.
+type rpcRequest struct {
+	ID     string                 `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// rpcResponse is the worker's reply to an rpcRequest.
+type rpcResponse struct {
+	ID     string          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// pythonBackend talks to a long-running Python worker process over
+// newline-delimited JSON-RPC on stdin/stdout, avoiding the interpreter
+// startup cost of spawning a fresh "python -c ..." per call. The worker is
+// restarted automatically if it crashes or stops responding to health
+// checks.
+type pythonBackend struct {
+	dbPath        string
+	pythonCommand string
+	workerScript  string
+
+	queue chan struct{} // bounds in-flight requests
+
+	mu      sync.Mutex // guards the fields below across restarts
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	pending map[string]chan rpcResponse
+
+	nextID int64
+
+	closed int32 // set via atomic; 1 once Close has been called
+
+	// onFailure, if set via SetFailureHook, is invoked once per detected
+	// worker crash/restart/unhealthy-ping/unreachable-stdin event, so
+	// every caller of a subprocess-backed method contributes to the same
+	// failure signal instead of each LocalKGBridge method having to
+	// remember to check for it itself.
+	onFailure atomic.Value // func()
+}
+
+// SetFailureHook registers fn to be called whenever the worker process is
+// detected to have crashed, failed a health check, or become unreachable.
+func (p *pythonBackend) SetFailureHook(fn func()) {
+	p.onFailure.Store(fn)
+}
+
+func (p *pythonBackend) reportFailure() {
+	if fn, ok := p.onFailure.Load().(func()); ok && fn != nil {
+		fn()
+	}
+}
+
+// newPythonBackend creates a pythonBackend and starts its worker process.
+// If the worker fails to start, calls will lazily retry on the next
+// request rather than failing construction.
+func newPythonBackend(dbPath, pythonCommand string) *pythonBackend {
+	p := &pythonBackend{
+		dbPath:        dbPath,
+		pythonCommand: pythonCommand,
+		workerScript:  "local_kg/bridges/worker.py",
+		queue:         make(chan struct{}, defaultWorkerQueueSize),
+		pending:       make(map[string]chan rpcResponse),
+	}
+
+	p.mu.Lock()
+	err := p.start()
+	p.mu.Unlock()
+	if err != nil {
+		// The first call will retry startup via call(); nothing to
+		// capture it to yet.
+		_ = err
+	}
+
+	go p.healthCheckLoop()
+
+	return p
+}
+
+// start spawns the worker process and its stdout-demultiplexing goroutine.
+// Callers must hold p.mu.
+func (p *pythonBackend) start() error {
+	cmd := exec.Command(p.pythonCommand, p.workerScript, p.dbPath)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("worker stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("worker stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("worker start: %w", err)
+	}
+
+	p.cmd = cmd
+	p.stdin = stdin
+
+	go p.readLoop(stdout)
+
+	return nil
+}
+
+// readLoop demultiplexes worker replies to their waiting caller and, on
+// EOF (worker crash or exit), fails every pending request and restarts the
+// worker with a short backoff.
+func (p *pythonBackend) readLoop(stdout io.ReadCloser) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		var resp rpcResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			continue
+		}
+
+		p.mu.Lock()
+		ch, ok := p.pending[resp.ID]
+		if ok {
+			delete(p.pending, resp.ID)
+		}
+		p.mu.Unlock()
+
+		if ok {
+			ch <- resp
+		}
+	}
+
+	p.failAllPending("python worker exited")
+
+	if atomic.LoadInt32(&p.closed) == 1 {
+		return
+	}
+	p.reportFailure()
+
+	backoff := 200 * time.Millisecond
+	for {
+		time.Sleep(backoff)
+		p.mu.Lock()
+		err := p.start()
+		p.mu.Unlock()
+		if err == nil {
+			return
+		}
+		if backoff < 5*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// healthCheckLoop periodically pings the worker; a failed ping forces a
+// restart so a hung-but-alive process doesn't silently swallow requests.
+func (p *pythonBackend) healthCheckLoop() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if atomic.LoadInt32(&p.closed) == 1 {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := p.call(ctx, "ping", nil)
+		cancel()
+		if err != nil {
+			p.mu.Lock()
+			if p.cmd != nil && p.cmd.Process != nil {
+				_ = p.cmd.Process.Kill()
+			}
+			p.mu.Unlock()
+			p.reportFailure()
+		}
+	}
+}
+
+func (p *pythonBackend) failAllPending(reason string) {
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = make(map[string]chan rpcResponse)
+	p.mu.Unlock()
+
+	for _, ch := range pending {
+		ch <- rpcResponse{Error: reason}
+	}
+}
+
+// call sends method(params) to the worker and waits for its response,
+// respecting ctx and the bounded request queue.
+func (p *pythonBackend) call(ctx context.Context, method string, params map[string]interface{}) (json.RawMessage, error) {
+	select {
+	case p.queue <- struct{}{}:
+		defer func() { <-p.queue }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	id := strconv.FormatInt(atomic.AddInt64(&p.nextID, 1), 10)
+	respCh := make(chan rpcResponse, 1)
+
+	p.mu.Lock()
+	if p.stdin == nil {
+		// The worker has never started successfully (stdin is only set
+		// by a successful start()); retry here so the "lazily retry on
+		// the next request" promise in
+		// newPythonBackend's doc comment actually holds.
+		if err := p.start(); err != nil {
+			p.mu.Unlock()
+			p.reportFailure()
+			return nil, fmt.Errorf("python worker is not running: %w", err)
+		}
+	}
+	p.pending[id] = respCh
+	stdin := p.stdin
+	p.mu.Unlock()
+
+	line, err := json.Marshal(rpcRequest{ID: id, Method: method, Params: params})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rpc request: %w", err)
+	}
+	line = append(line, '\n')
+
+	if _, err := stdin.Write(line); err != nil {
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		p.reportFailure()
+		return nil, fmt.Errorf("failed to write to python worker: %w", err)
+	}
+
+	select {
+	case resp := <-respCh:
+		if resp.Error != "" {
+			return nil, fmt.Errorf("python worker error: %s", resp.Error)
+		}
+		return resp.Result, nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (p *pythonBackend) CaptureError(ctx context.Context, c ErrorCapture) (string, error) {
+	result, err := p.call(ctx, "capture_error", map[string]interface{}{
+		"error_signature": c.Signature,
+		"error_category":  c.Category,
+		"language":        c.Language,
+		"severity":        c.Severity,
+		"description":     c.Description,
+	})
+	if err != nil {
+		return "", err
+	}
+	return unquoteJSONString(result)
+}
+
+func (p *pythonBackend) SearchPatterns(ctx context.Context, query string, limit int) ([]ErrorPattern, error) {
+	result, err := p.call(ctx, "search_patterns", map[string]interface{}{
+		"query": query,
+		"limit": int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []ErrorPattern
+	if err := json.Unmarshal(result, &patterns); err != nil {
+		return nil, fmt.Errorf("failed to parse patterns: %w", err)
+	}
+	return patterns, nil
+}
+
+func (p *pythonBackend) AddSolution(ctx context.Context, patternID, solutionText, effectiveness string) (string, error) {
+	result, err := p.call(ctx, "add_solution", map[string]interface{}{
+		"pattern_id":    patternID,
+		"solution_text": solutionText,
+		"effectiveness": effectiveness,
+	})
+	if err != nil {
+		return "", err
+	}
+	return unquoteJSONString(result)
+}
+
+func (p *pythonBackend) GetSummary(ctx context.Context) (*PatternSummary, error) {
+	result, err := p.call(ctx, "get_pattern_summary", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary PatternSummary
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse summary: %w", err)
+	}
+	return &summary, nil
+}
+
+func (p *pythonBackend) CaptureErrorWithParams(ctx context.Context, params map[string]interface{}) (string, error) {
+	// The worker's capture_error takes error_signature/error_category, the
+	// same keys CaptureError sends, so translate the generic param names.
+	workerParams := make(map[string]interface{}, len(params)+2)
+	for k, v := range params {
+		workerParams[k] = v
+	}
+	if v, ok := params["signature"]; ok {
+		workerParams["error_signature"] = v
+	}
+	if v, ok := params["category"]; ok {
+		workerParams["error_category"] = v
+	}
+
+	result, err := p.call(ctx, "capture_error", workerParams)
+	if err != nil {
+		return "", err
+	}
+	return unquoteJSONString(result)
+}
+
+func (p *pythonBackend) SearchPatternsWithParams(ctx context.Context, params map[string]interface{}) ([]ErrorPattern, error) {
+	result, err := p.call(ctx, "search_patterns", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []ErrorPattern
+	if err := json.Unmarshal(result, &patterns); err != nil {
+		return nil, fmt.Errorf("failed to parse patterns: %w", err)
+	}
+	return patterns, nil
+}
+
+func (p *pythonBackend) Close() error {
+	atomic.StoreInt32(&p.closed, 1)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stdin != nil {
+		_ = p.stdin.Close()
+	}
+	if p.cmd != nil && p.cmd.Process != nil {
+		_ = p.cmd.Process.Kill()
+	}
+	return nil
+}
+
+// unquoteJSONString decodes a JSON-encoded string result, the shape every
+// worker method that returns a bare ID uses.
+func unquoteJSONString(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", fmt.Errorf("failed to parse worker result: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,40 @@
+package bridges
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewPythonBackendRetriesStartupOnNextCall(t *testing.T) {
+	// pythonCommand deliberately doesn't exist, so construction fails to
+	// start the worker the way a missing interpreter or bad workerScript
+	// path would.
+	p := &pythonBackend{
+		dbPath:        t.TempDir() + "/kg.db",
+		pythonCommand: "nebula-protocol-nonexistent-python-binary",
+		workerScript:  "local_kg/bridges/worker.py",
+		queue:         make(chan struct{}, defaultWorkerQueueSize),
+		pending:       make(map[string]chan rpcResponse),
+	}
+
+	p.mu.Lock()
+	err := p.start()
+	p.mu.Unlock()
+	if err == nil {
+		t.Fatal("expected start() to fail for a nonexistent python command")
+	}
+	if p.stdin != nil {
+		t.Fatal("stdin should be nil after a failed start()")
+	}
+
+	// call() must itself retry start() rather than permanently reporting
+	// "python worker is not running" with no recovery path.
+	_, callErr := p.call(context.Background(), "ping", nil)
+	if callErr == nil {
+		t.Fatal("expected call() to fail since the worker still can't start")
+	}
+	if !strings.Contains(callErr.Error(), "worker start") {
+		t.Fatalf("call() error = %q, want it to surface the retried start() failure (containing %q)", callErr, "worker start")
+	}
+}
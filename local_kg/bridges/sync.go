@@ -0,0 +1,373 @@
+package bridges
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// syncPushInterval is how often pending outbox entries are flushed to
+// CentralKgUrl in the steady state; it backs off on failure and resets
+// once a push succeeds.
+const syncPushInterval = 30 * time.Second
+
+// outboxEntry is one pattern or solution queued for delivery to the
+// central KG. Entries are kept until the push succeeds, giving
+// at-least-once delivery across restarts.
+type outboxEntry struct {
+	ID        string
+	Kind      string // "pattern" or "solution"
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+// syncRecord is the wire format pushed to / pulled from CentralKgUrl.
+type syncRecord struct {
+	Kind    string          `json:"kind"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// solutionPayload is the outbox/wire shape for a solution record.
+type solutionPayload struct {
+	PatternID     string `json:"pattern_id"`
+	SolutionText  string `json:"solution_text"`
+	Effectiveness string `json:"effectiveness"`
+}
+
+// SyncStatus reports the health of the central KG sync subsystem.
+type SyncStatus struct {
+	Running       bool      `json:"running"`
+	LastSyncedAt  time.Time `json:"last_synced_at"`
+	PendingOutbox int       `json:"pending_outbox"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+// syncState holds the sync subsystem's runtime state; nil on a
+// LocalKGBridge until StartSync succeeds.
+type syncState struct {
+	db         *sql.DB
+	centralURL string
+	httpClient *http.Client
+	cancel     context.CancelFunc
+
+	mu     sync.Mutex
+	status SyncStatus
+}
+
+// StartSync launches a background goroutine that batches newly captured
+// patterns and solutions and POSTs them to config.CentralKgUrl, and begins
+// periodically pulling community-known patterns via PullFromCentral. It
+// requires config.CentralKgUrl but does not look at config.AutoSync; the
+// NewLocalKGBridge and NewNebulaLocalKGBridge constructors call it
+// (through startAutoSync) when AutoSync is true.
+func (kg *LocalKGBridge) StartSync(ctx context.Context) error {
+	if kg.config.CentralKgUrl == "" {
+		return fmt.Errorf("sync: central_kg_url is not configured")
+	}
+
+	kg.syncMu.Lock()
+	defer kg.syncMu.Unlock()
+
+	if kg.sync != nil {
+		return fmt.Errorf("sync: already started")
+	}
+
+	db, err := openOutbox(kg.dbPath)
+	if err != nil {
+		return err
+	}
+
+	syncCtx, cancel := context.WithCancel(ctx)
+	state := &syncState{
+		db:         db,
+		centralURL: kg.config.CentralKgUrl,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		cancel:     cancel,
+		status:     SyncStatus{Running: true},
+	}
+	kg.sync = state
+
+	go kg.syncLoop(syncCtx, state)
+
+	return nil
+}
+
+// StopSync cancels the background sync goroutine, if running.
+func (kg *LocalKGBridge) StopSync() {
+	kg.syncMu.Lock()
+	defer kg.syncMu.Unlock()
+
+	if kg.sync == nil {
+		return
+	}
+	kg.sync.cancel()
+	kg.sync.mu.Lock()
+	kg.sync.status.Running = false
+	kg.sync.mu.Unlock()
+	_ = kg.sync.db.Close()
+}
+
+// SyncStatus reports the current state of the sync subsystem. The zero
+// value (Running: false) is returned if sync was never started.
+func (kg *LocalKGBridge) SyncStatus() SyncStatus {
+	kg.syncMu.Lock()
+	state := kg.sync
+	kg.syncMu.Unlock()
+
+	if state == nil {
+		return SyncStatus{}
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.status
+}
+
+// startAutoSync honors config.AutoSync by starting the sync subsystem at
+// construction time. It is a silent no-op if AutoSync is off or
+// CentralKgUrl isn't set, since most bridges never touch sync at all; a
+// StartSync failure is logged to stderr, not returned.
+func (kg *LocalKGBridge) startAutoSync() {
+	if !kg.config.AutoSync || kg.config.CentralKgUrl == "" {
+		return
+	}
+	if err := kg.StartSync(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "[LocalKG] auto_sync enabled but failed to start: %v\n", err)
+	}
+}
+
+func (kg *LocalKGBridge) syncLoop(ctx context.Context, state *syncState) {
+	backoff := syncPushInterval
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if err := kg.pushOutbox(ctx, state); err != nil {
+			state.mu.Lock()
+			state.status.LastError = err.Error()
+			state.mu.Unlock()
+			if backoff < 5*time.Minute {
+				backoff *= 2
+			}
+			continue
+		}
+
+		if err := kg.PullFromCentral(ctx); err != nil {
+			state.mu.Lock()
+			state.status.LastError = err.Error()
+			state.mu.Unlock()
+		}
+
+		backoff = syncPushInterval
+	}
+}
+
+// enqueueOutbox records a pattern/solution for later delivery to the
+// central KG. It is a no-op if sync hasn't been started.
+func (kg *LocalKGBridge) enqueueOutbox(kind string, payload interface{}) {
+	kg.syncMu.Lock()
+	state := kg.sync
+	kg.syncMu.Unlock()
+
+	if state == nil {
+		return
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	id := newPatternID(kind, string(data), time.Now().String())
+	_, _ = state.db.Exec(
+		`INSERT INTO outbox(id, kind, payload, created_at, delivered) VALUES (?, ?, ?, ?, 0)`,
+		id, kind, string(data), time.Now().UTC().Format(time.RFC3339),
+	)
+}
+
+// pushOutbox delivers the oldest undelivered outbox entries (at most 100
+// per call) to CentralKgUrl as a single batch, marking delivered rows only
+// once the server acknowledges
+// the whole batch (at-least-once: a retry after a partial failure may
+// resend already-applied rows, which the server is expected to dedupe by
+// (error_signature, language)).
+func (kg *LocalKGBridge) pushOutbox(ctx context.Context, state *syncState) error {
+	rows, err := state.db.QueryContext(ctx, `SELECT id, kind, payload FROM outbox WHERE delivered = 0 ORDER BY created_at LIMIT 100`)
+	if err != nil {
+		return fmt.Errorf("sync: failed to read outbox: %w", err)
+	}
+
+	var ids []string
+	var records []syncRecord
+	for rows.Next() {
+		var id, kind, payload string
+		if err := rows.Scan(&id, &kind, &payload); err != nil {
+			rows.Close()
+			return fmt.Errorf("sync: failed to scan outbox row: %w", err)
+		}
+		ids = append(ids, id)
+		records = append(records, syncRecord{Kind: kind, Payload: json.RawMessage(payload)})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return kg.markSynced(ctx, state)
+	}
+
+	body, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("sync: failed to marshal batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, state.centralURL+"/sync/push", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("sync: failed to build push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := state.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sync: push request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("sync: push rejected with status %d", resp.StatusCode)
+	}
+
+	placeholders := make([]interface{}, len(ids))
+	query := `UPDATE outbox SET delivered = 1 WHERE id IN (`
+	for i, id := range ids {
+		if i > 0 {
+			query += ", "
+		}
+		query += "?"
+		placeholders[i] = id
+	}
+	query += ")"
+
+	if _, err := state.db.ExecContext(ctx, query, placeholders...); err != nil {
+		return fmt.Errorf("sync: failed to mark outbox delivered: %w", err)
+	}
+
+	return kg.markSynced(ctx, state)
+}
+
+func (kg *LocalKGBridge) markSynced(ctx context.Context, state *syncState) error {
+	now := time.Now().UTC()
+	if _, err := state.db.ExecContext(ctx, `UPDATE sync_meta SET last_synced_at = ?`, now.Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("sync: failed to update watermark: %w", err)
+	}
+
+	var pending int
+	_ = state.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM outbox WHERE delivered = 0`).Scan(&pending)
+
+	state.mu.Lock()
+	state.status.LastSyncedAt = now
+	state.status.PendingOutbox = pending
+	state.status.LastError = ""
+	state.mu.Unlock()
+
+	return nil
+}
+
+// PullFromCentral downloads the patterns the central KG has learned about
+// since the last watermark and merges them into the local backend, keyed
+// by (error_signature, language) so a pattern already known locally is
+// merged instead of being duplicated. Solutions are not pulled.
+func (kg *LocalKGBridge) PullFromCentral(ctx context.Context) error {
+	kg.syncMu.Lock()
+	state := kg.sync
+	kg.syncMu.Unlock()
+	if state == nil {
+		return fmt.Errorf("sync: not started")
+	}
+
+	var since string
+	_ = state.db.QueryRowContext(ctx, `SELECT last_synced_at FROM sync_meta`).Scan(&since)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, state.centralURL+"/sync/pull?since="+since, nil)
+	if err != nil {
+		return fmt.Errorf("sync: failed to build pull request: %w", err)
+	}
+
+	resp, err := state.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sync: pull request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("sync: pull rejected with status %d", resp.StatusCode)
+	}
+
+	var patterns []ErrorPattern
+	if err := json.NewDecoder(resp.Body).Decode(&patterns); err != nil {
+		return fmt.Errorf("sync: failed to decode pull response: %w", err)
+	}
+
+	for _, p := range patterns {
+		// CaptureError merges on (error_signature, language) in every
+		// Backend implementation (bumping occurrence_count / last_seen),
+		// so re-applying a remote pattern we already have is a no-op
+		// other than that bump.
+		if _, err := kg.backend.CaptureError(ctx, ErrorCapture{
+			Signature:   p.ErrorSignature,
+			Category:    p.ErrorCategory,
+			Language:    p.Language,
+			Severity:    p.Severity,
+			Description: p.Description,
+		}); err != nil {
+			return fmt.Errorf("sync: failed to merge pattern %s: %w", p.ErrorSignature, err)
+		}
+	}
+
+	return nil
+}
+
+// openOutbox opens (creating if needed) the sqlite-backed outbox used to
+// stage records for central sync, independent of which Backend the bridge
+// is otherwise using.
+func openOutbox(dbPath string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dbPath+".outbox")
+	if err != nil {
+		return nil, fmt.Errorf("sync: failed to open outbox: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS outbox (
+			id         TEXT PRIMARY KEY,
+			kind       TEXT NOT NULL,
+			payload    TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			delivered  INTEGER NOT NULL DEFAULT 0
+		);
+		CREATE TABLE IF NOT EXISTS sync_meta (last_synced_at TEXT);
+	`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sync: failed to create outbox schema: %w", err)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sync_meta`).Scan(&count); err == nil && count == 0 {
+		_, _ = db.Exec(`INSERT INTO sync_meta(last_synced_at) VALUES ('')`)
+	}
+
+	return db, nil
+}
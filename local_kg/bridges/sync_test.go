@@ -0,0 +1,106 @@
+package bridges
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestSyncState opens a fresh outbox database and points it at srv,
+// mirroring what StartSync wires up but without requiring a full
+// LocalKGBridge or config.CentralKgUrl.
+func newTestSyncState(t *testing.T, srv *httptest.Server) *syncState {
+	t.Helper()
+	db, err := openOutbox(filepath.Join(t.TempDir(), "sync"))
+	if err != nil {
+		t.Fatalf("openOutbox() error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return &syncState{
+		db:         db,
+		centralURL: srv.URL,
+		httpClient: srv.Client(),
+	}
+}
+
+func TestPushOutboxDeliversPendingEntriesOnce(t *testing.T) {
+	var pushes int32
+	var lastBatch []syncRecord
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&pushes, 1)
+		if err := json.NewDecoder(r.Body).Decode(&lastBatch); err != nil {
+			t.Errorf("server failed to decode push body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	state := newTestSyncState(t, srv)
+	kg := &LocalKGBridge{sync: state}
+
+	kg.enqueueOutbox("pattern", ErrorCapture{Signature: "boom", Language: "go"})
+	kg.enqueueOutbox("solution", solutionPayload{PatternID: "p1", SolutionText: "fix"})
+
+	ctx := context.Background()
+	if err := kg.pushOutbox(ctx, state); err != nil {
+		t.Fatalf("first pushOutbox() error: %v", err)
+	}
+	if got := atomic.LoadInt32(&pushes); got != 1 {
+		t.Fatalf("server received %d push requests, want 1", got)
+	}
+	if len(lastBatch) != 2 {
+		t.Fatalf("first push batch had %d records, want 2", len(lastBatch))
+	}
+
+	// A second push with nothing new pending must not re-send the
+	// already-delivered entries.
+	if err := kg.pushOutbox(ctx, state); err != nil {
+		t.Fatalf("second pushOutbox() error: %v", err)
+	}
+	if got := atomic.LoadInt32(&pushes); got != 1 {
+		t.Fatalf("server received %d push requests after a no-op push, want still 1 (delivered rows must not be resent)", got)
+	}
+}
+
+func TestPullFromCentralMergesRepeatedPatternsWithoutDuplicating(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		patterns := []ErrorPattern{
+			{ErrorSignature: "boom", ErrorCategory: "RuntimeError", Language: "go", Severity: "high"},
+		}
+		json.NewEncoder(w).Encode(patterns)
+	}))
+	defer srv.Close()
+
+	backend, err := newSQLiteBackend(filepath.Join(t.TempDir(), "offline.db"))
+	if err != nil {
+		t.Fatalf("newSQLiteBackend() error: %v", err)
+	}
+	defer backend.Close()
+
+	state := newTestSyncState(t, srv)
+	kg := &LocalKGBridge{backend: backend, sync: state}
+
+	ctx := context.Background()
+	if err := kg.PullFromCentral(ctx); err != nil {
+		t.Fatalf("first PullFromCentral() error: %v", err)
+	}
+	if err := kg.PullFromCentral(ctx); err != nil {
+		t.Fatalf("second PullFromCentral() error: %v", err)
+	}
+
+	patterns, err := backend.SearchPatterns(ctx, "boom", 10)
+	if err != nil {
+		t.Fatalf("SearchPatterns() error: %v", err)
+	}
+	if len(patterns) != 1 {
+		t.Fatalf("SearchPatterns() found %d patterns after two pulls of the same remote pattern, want exactly 1", len(patterns))
+	}
+	if patterns[0].OccurrenceCount != 2 {
+		t.Fatalf("OccurrenceCount = %d after two pulls, want 2 (merged, not duplicated)", patterns[0].OccurrenceCount)
+	}
+}